@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secmon-as-code/overseer/pkg/cli/config/cache"
+	"github.com/urfave/cli/v3"
+)
+
+func cmdCache() *cli.Command {
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "Inspect and manage the query result cache",
+		Commands: []*cli.Command{
+			cmdCacheStats(),
+		},
+	}
+}
+
+func cmdCacheStats() *cli.Command {
+	var cacheCfg cache.Config
+
+	action := func(ctx context.Context, c *cli.Command) error {
+		cacheSvc, err := cacheCfg.Build(ctx, "")
+		if err != nil {
+			return err
+		}
+
+		stats, err := cacheSvc.Stats(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("hits:   %d\n", stats.Hits)
+		fmt.Printf("misses: %d\n", stats.Misses)
+
+		return nil
+	}
+
+	return &cli.Command{
+		Name:   "stats",
+		Usage:  "Report cache hit/miss counts",
+		Flags:  cacheCfg.Flags(),
+		Action: action,
+	}
+}
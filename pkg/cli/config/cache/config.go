@@ -0,0 +1,322 @@
+// Package cache configures the storage used by cmdEval to persist BigQuery
+// result rows between runs, keyed by the content of the query that produced
+// them so unchanged queries against unchanged tables can skip BigQuery
+// entirely.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m-mizutani/goerr"
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+	"github.com/urfave/cli/v3"
+)
+
+// Mode controls whether Service.Load and Service.Save are permitted to touch
+// the underlying cache.
+type Mode string
+
+const (
+	ModeOff   Mode = "off"   // never read or write the query result cache
+	ModeRead  Mode = "read"  // replay from cache when possible, never write
+	ModeWrite Mode = "write" // always query BigQuery, but persist the result
+	ModeRW    Mode = "rw"    // read when possible, write otherwise (default)
+)
+
+// Stats reports cumulative Load outcomes for the `overseer cache stats` command.
+type Stats struct {
+	Hits   int `json:"hits"`
+	Misses int `json:"misses"`
+}
+
+// Service stores and replays the BigQuery rows produced by a query, and
+// provides general-purpose key/value storage for other eval-time state such
+// as alert deduplication counters.
+type Service interface {
+	// Key derives the content-addressed cache key for a query.
+	Key(meta model.QueryMeta) string
+
+	// Load returns cached rows for key if present and, when ttl was configured,
+	// not yet expired. When a cache directory is configured, every call updates
+	// the hit/miss counters reported by Stats.
+	Load(ctx context.Context, key string) ([]model.Row, bool, error)
+
+	// Save stores rows under key.
+	Save(ctx context.Context, key string, rows []model.Row) error
+
+	// Get returns the raw bytes stored under key, if any. Unaffected by Mode/TTL.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key, overwriting any previous value. Unaffected by Mode/TTL.
+	Set(ctx context.Context, key string, value []byte) error
+
+	// Stats reports cumulative Load hit/miss counts.
+	Stats(ctx context.Context) (Stats, error)
+}
+
+// Config holds CLI configuration for the cache service used by cmdEval.
+type Config struct {
+	// Dir is a directory path where cached query rows are stored.
+	Dir string
+
+	// TTL is how long a cached result set remains eligible for replay. Zero
+	// means cached rows never expire.
+	TTL time.Duration
+
+	// Mode controls whether the cache is read from, written to, both, or ignored.
+	Mode Mode
+}
+
+func (x *Config) Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "cache-dir",
+			Usage:       "Directory path to store cached query rows",
+			Category:    "cache",
+			Destination: &x.Dir,
+			Sources:     cli.NewValueSourceChain(cli.EnvVar("OVERSEER_CACHE_DIR")),
+		},
+		&cli.DurationFlag{
+			Name:        "cache-ttl",
+			Usage:       "How long a cached query result remains eligible for replay, e.g. 24h (0 means it never expires)",
+			Category:    "cache",
+			Destination: &x.TTL,
+			Sources:     cli.NewValueSourceChain(cli.EnvVar("OVERSEER_CACHE_TTL")),
+		},
+		&cli.StringFlag{
+			Name:        "cache-mode",
+			Usage:       "Cache mode: off, read, write, or rw",
+			Category:    "cache",
+			Value:       string(ModeRW),
+			Destination: (*string)(&x.Mode),
+			Sources:     cli.NewValueSourceChain(cli.EnvVar("OVERSEER_CACHE_MODE")),
+		},
+	}
+}
+
+// Build constructs a Service. jobID is not used: cached query rows are keyed by
+// Service.Key, and Get/Set state by the caller's key alone.
+func (x *Config) Build(ctx context.Context, jobID model.JobID) (Service, error) {
+	mode := x.Mode
+	if mode == "" {
+		mode = ModeRW
+	}
+
+	switch mode {
+	case ModeOff, ModeRead, ModeWrite, ModeRW:
+	default:
+		return nil, goerr.New("unknown cache mode").With("mode", mode)
+	}
+
+	if x.Dir == "" {
+		return &noopService{}, nil
+	}
+
+	return &fileService{dir: x.Dir, ttl: x.TTL, mode: mode}, nil
+}
+
+// Key derives a content-addressed cache key from a query's SQL text and the
+// snapshot IDs of the tables it reads, so the same query against unchanged
+// tables always maps to the same key. The SQL text is hashed as-is apart from
+// trimming leading and trailing whitespace: further reformatting
+// it (collapsing whitespace, lower-casing) would reach inside string and
+// identifier literals and could map two distinct queries (e.g. ones that differ
+// only in the case of a quoted literal) onto the same key.
+func Key(meta model.QueryMeta) string {
+	h := sha256.New()
+	h.Write([]byte(strings.TrimSpace(meta.SQL)))
+
+	ids := append([]string(nil), meta.SnapshotIDs...)
+	sort.Strings(ids)
+	for _, id := range ids {
+		h.Write([]byte{0})
+		h.Write([]byte(id))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// noopService is used when no cache directory is configured; every eval run
+// queries BigQuery fresh and nothing, including Get/Set state, is persisted.
+type noopService struct{}
+
+func (x *noopService) Key(meta model.QueryMeta) string { return Key(meta) }
+
+func (x *noopService) Load(ctx context.Context, key string) ([]model.Row, bool, error) {
+	return nil, false, nil
+}
+func (x *noopService) Save(ctx context.Context, key string, rows []model.Row) error { return nil }
+
+func (x *noopService) Get(ctx context.Context, key string) ([]byte, bool, error) { return nil, false, nil }
+func (x *noopService) Set(ctx context.Context, key string, value []byte) error   { return nil }
+
+func (x *noopService) Stats(ctx context.Context) (Stats, error) { return Stats{}, nil }
+
+// fileService persists rows and state as JSON files under dir, and tracks
+// Load hit/miss counters in a stats file alongside them.
+type fileService struct {
+	dir  string
+	ttl  time.Duration
+	mode Mode
+}
+
+type cacheEntry struct {
+	CachedAt time.Time   `json:"cached_at"`
+	Rows     []model.Row `json:"rows"`
+}
+
+func (x *fileService) Key(meta model.QueryMeta) string { return Key(meta) }
+
+func (x *fileService) rowsPath(key string) string {
+	return filepath.Join(x.dir, "rows", key+".json")
+}
+
+func (x *fileService) canRead() bool  { return x.mode == ModeRead || x.mode == ModeRW }
+func (x *fileService) canWrite() bool { return x.mode == ModeWrite || x.mode == ModeRW }
+
+func (x *fileService) Load(ctx context.Context, key string) ([]model.Row, bool, error) {
+	rows, hit, err := x.load(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := x.recordStat(hit); err != nil {
+		return nil, false, err
+	}
+
+	return rows, hit, nil
+}
+
+func (x *fileService) load(key string) ([]model.Row, bool, error) {
+	if !x.canRead() {
+		return nil, false, nil
+	}
+
+	buf, err := os.ReadFile(x.rowsPath(key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, goerr.Wrap(err, "fail to read cached rows").With("key", key)
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(buf, &entry); err != nil {
+		return nil, false, goerr.Wrap(err, "fail to decode cached rows").With("key", key)
+	}
+
+	if x.ttl > 0 && time.Since(entry.CachedAt) > x.ttl {
+		return nil, false, nil
+	}
+
+	return entry.Rows, true, nil
+}
+
+func (x *fileService) Save(ctx context.Context, key string, rows []model.Row) error {
+	if !x.canWrite() {
+		return nil
+	}
+
+	path := x.rowsPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return goerr.Wrap(err, "fail to create cache directory").With("dir", filepath.Dir(path))
+	}
+
+	buf, err := json.Marshal(cacheEntry{CachedAt: time.Now(), Rows: rows})
+	if err != nil {
+		return goerr.Wrap(err, "fail to encode rows for cache")
+	}
+
+	if err := os.WriteFile(path, buf, 0644); err != nil {
+		return goerr.Wrap(err, "fail to write cached rows").With("path", path)
+	}
+
+	return nil
+}
+
+func (x *fileService) statePath(key string) string {
+	return filepath.Join(x.dir, "state", key+".json")
+}
+
+func (x *fileService) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	buf, err := os.ReadFile(x.statePath(key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, goerr.Wrap(err, "fail to read cache state").With("key", key)
+	}
+
+	return buf, true, nil
+}
+
+func (x *fileService) Set(ctx context.Context, key string, value []byte) error {
+	path := x.statePath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return goerr.Wrap(err, "fail to create cache state directory").With("path", path)
+	}
+
+	if err := os.WriteFile(path, value, 0644); err != nil {
+		return goerr.Wrap(err, "fail to write cache state").With("path", path)
+	}
+
+	return nil
+}
+
+func (x *fileService) statsPath() string {
+	return filepath.Join(x.dir, "stats.json")
+}
+
+func (x *fileService) recordStat(hit bool) error {
+	stats, err := x.readStats()
+	if err != nil {
+		return err
+	}
+
+	if hit {
+		stats.Hits++
+	} else {
+		stats.Misses++
+	}
+
+	buf, err := json.Marshal(stats)
+	if err != nil {
+		return goerr.Wrap(err, "fail to encode cache stats")
+	}
+
+	if err := os.MkdirAll(x.dir, 0755); err != nil {
+		return goerr.Wrap(err, "fail to create cache directory").With("dir", x.dir)
+	}
+
+	if err := os.WriteFile(x.statsPath(), buf, 0644); err != nil {
+		return goerr.Wrap(err, "fail to write cache stats").With("path", x.statsPath())
+	}
+
+	return nil
+}
+
+func (x *fileService) readStats() (Stats, error) {
+	buf, err := os.ReadFile(x.statsPath())
+	if os.IsNotExist(err) {
+		return Stats{}, nil
+	} else if err != nil {
+		return Stats{}, goerr.Wrap(err, "fail to read cache stats").With("path", x.statsPath())
+	}
+
+	var stats Stats
+	if err := json.Unmarshal(buf, &stats); err != nil {
+		return Stats{}, goerr.Wrap(err, "fail to decode cache stats").With("path", x.statsPath())
+	}
+
+	return stats, nil
+}
+
+func (x *fileService) Stats(ctx context.Context) (Stats, error) {
+	return x.readStats()
+}
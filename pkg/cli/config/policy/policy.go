@@ -0,0 +1,113 @@
+// Package policy configures the detection sources (Rego policies, Sigma rules)
+// that usecase.Eval evaluates query result rows against.
+package policy
+
+import (
+	"context"
+
+	"github.com/m-mizutani/goerr"
+	"github.com/secmon-as-code/overseer/pkg/cli/config/policy/sigma"
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+	"github.com/urfave/cli/v3"
+)
+
+// Service evaluates a query result row against a set of detection rules and
+// returns any alerts that should be raised.
+type Service interface {
+	Evaluate(ctx context.Context, row model.Row) ([]*model.Alert, error)
+}
+
+// Config holds CLI configuration for the detection policies used by cmdEval.
+type Config struct {
+	// RegoDir is a directory path that contains Rego policy files.
+	RegoDir string
+
+	// SigmaDir is a directory path that contains Sigma detection rule files (YAML).
+	SigmaDir string
+}
+
+func (x *Config) Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "policy-dir",
+			Usage:       "Directory path of Rego policy files",
+			Category:    "policy",
+			Destination: &x.RegoDir,
+			Sources:     cli.NewValueSourceChain(cli.EnvVar("OVERSEER_POLICY_DIR")),
+		},
+		&cli.StringFlag{
+			Name:        "sigma-dir",
+			Usage:       "Directory path of Sigma detection rule files",
+			Category:    "policy",
+			Destination: &x.SigmaDir,
+			Sources:     cli.NewValueSourceChain(cli.EnvVar("OVERSEER_SIGMA_DIR")),
+		},
+	}
+}
+
+// Build constructs a Service from the configured policy sources. Rego and Sigma
+// sources can be combined; a row is evaluated against both when configured.
+func (x *Config) Build() (Service, error) {
+	var services []Service
+
+	if x.RegoDir != "" {
+		svc, err := newRegoService(x.RegoDir)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, svc)
+	}
+
+	if x.SigmaDir != "" {
+		rules, err := sigma.LoadDir(x.SigmaDir)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, &sigmaService{rules: rules})
+	}
+
+	if len(services) == 0 {
+		return nil, goerr.New("no policy source configured, specify --policy-dir and/or --sigma-dir")
+	}
+	if len(services) == 1 {
+		return services[0], nil
+	}
+
+	return multiService(services), nil
+}
+
+// multiService fans a row out to every configured Service and merges the alerts.
+type multiService []Service
+
+func (x multiService) Evaluate(ctx context.Context, row model.Row) ([]*model.Alert, error) {
+	var alerts []*model.Alert
+	for _, svc := range x {
+		found, err := svc.Evaluate(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, found...)
+	}
+	return alerts, nil
+}
+
+// sigmaService evaluates a row against a set of compiled Sigma rules.
+type sigmaService struct {
+	rules []*sigma.Rule
+}
+
+func (x *sigmaService) Evaluate(ctx context.Context, row model.Row) ([]*model.Alert, error) {
+	var alerts []*model.Alert
+	for _, rule := range x.rules {
+		if !rule.Match(row) {
+			continue
+		}
+
+		alert, err := model.NewAlert(ctx, rule.Alert())
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, nil
+}
@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/m-mizutani/goerr"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+)
+
+// regoService evaluates a query result row against a directory of Rego policies.
+type regoService struct {
+	query rego.PreparedEvalQuery
+}
+
+func newRegoService(dir string) (*regoService, error) {
+	r := rego.New(
+		rego.Query("data.overseer.alerts"),
+		rego.Load([]string{dir}, nil),
+	)
+
+	q, err := r.PrepareForEval(context.Background())
+	if err != nil {
+		return nil, goerr.Wrap(err, "fail to load rego policies").With("dir", dir)
+	}
+
+	return &regoService{query: q}, nil
+}
+
+func (x *regoService) Evaluate(ctx context.Context, row model.Row) ([]*model.Alert, error) {
+	rs, err := x.query.Eval(ctx, rego.EvalInput(map[string]any(row)))
+	if err != nil {
+		return nil, goerr.Wrap(err, "fail to evaluate rego policy")
+	}
+
+	var alerts []*model.Alert
+	for _, r := range rs {
+		for _, expr := range r.Expressions {
+			bodies, ok := expr.Value.([]any)
+			if !ok {
+				continue
+			}
+
+			for _, b := range bodies {
+				body, err := decodeAlertBody(b)
+				if err != nil {
+					return nil, err
+				}
+
+				alert, err := model.NewAlert(ctx, body)
+				if err != nil {
+					return nil, err
+				}
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+
+	return alerts, nil
+}
+
+func decodeAlertBody(v any) (model.AlertBody, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return model.AlertBody{}, goerr.Wrap(err, "fail to marshal rego alert result")
+	}
+
+	var body model.AlertBody
+	if err := json.Unmarshal(buf, &body); err != nil {
+		return model.AlertBody{}, goerr.Wrap(err, "fail to decode rego alert result")
+	}
+
+	return body, nil
+}
@@ -0,0 +1,367 @@
+package sigma
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/m-mizutani/goerr"
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+)
+
+// Condition is a compiled boolean expression evaluable against a result row.
+type Condition interface {
+	Eval(row model.Row) bool
+}
+
+type andCondition []Condition
+
+func (x andCondition) Eval(row model.Row) bool {
+	for _, c := range x {
+		if !c.Eval(row) {
+			return false
+		}
+	}
+	return true
+}
+
+type orCondition []Condition
+
+func (x orCondition) Eval(row model.Row) bool {
+	for _, c := range x {
+		if c.Eval(row) {
+			return true
+		}
+	}
+	return false
+}
+
+type notCondition struct{ inner Condition }
+
+func (x notCondition) Eval(row model.Row) bool { return !x.inner.Eval(row) }
+
+// fieldCondition matches a single field against a value using a Sigma modifier
+// (contains, startswith, endswith, re, all, null; bare equality otherwise).
+// Per the Sigma spec, string matching is case-insensitive unless the "cased"
+// modifier is present alongside it. A list value ORs the field against each
+// element for every modifier except "all", which ANDs them instead.
+type fieldCondition struct {
+	field    string
+	modifier string
+	value    any
+	cased    bool
+
+	// re is the pre-compiled pattern for modifier "re", compiled once at rule
+	// load time rather than per evaluated row.
+	re *regexp.Regexp
+}
+
+func (x fieldCondition) Eval(row model.Row) bool {
+	v, ok := row[x.field]
+
+	if x.modifier == "null" {
+		want, _ := x.value.(bool)
+		return (!ok || v == nil) == want
+	}
+
+	if !ok {
+		return false
+	}
+
+	if x.modifier == "re" {
+		return x.re.MatchString(toString(v))
+	}
+
+	sv := x.normalize(toString(v))
+
+	if x.modifier == "all" {
+		values, ok := x.value.([]any)
+		if !ok {
+			return false
+		}
+		for _, want := range values {
+			if !strings.Contains(sv, x.normalize(toString(want))) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if values, ok := x.value.([]any); ok {
+		for _, want := range values {
+			if x.matchOne(sv, want) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return x.matchOne(sv, x.value)
+}
+
+// matchOne applies the field's modifier (contains/startswith/endswith/bare
+// equality) to a single candidate value.
+func (x fieldCondition) matchOne(sv string, want any) bool {
+	w := x.normalize(toString(want))
+
+	switch x.modifier {
+	case "contains":
+		return strings.Contains(sv, w)
+	case "startswith":
+		return strings.HasPrefix(sv, w)
+	case "endswith":
+		return strings.HasSuffix(sv, w)
+	default:
+		return sv == w
+	}
+}
+
+// normalize lower-cases s unless the field carries the "cased" modifier,
+// matching Sigma's default case-insensitive string matching.
+func (x fieldCondition) normalize(s string) string {
+	if x.cased {
+		return s
+	}
+	return strings.ToLower(s)
+}
+
+func toString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// compile turns a Sigma "detection" block into a Condition tree. It supports
+// the common subset of the Sigma condition grammar: named selections combined
+// with "and"/"or"/"not", grouping parentheses, and "prefix*" wildcard references.
+func compile(detection map[string]any) (Condition, error) {
+	raw, ok := detection["condition"]
+	if !ok {
+		return nil, goerr.New("detection.condition is required")
+	}
+	expr, ok := raw.(string)
+	if !ok {
+		return nil, goerr.New("detection.condition must be a string")
+	}
+
+	selections := map[string]Condition{}
+	for name, def := range detection {
+		if name == "condition" {
+			continue
+		}
+
+		sel, err := compileSelection(def)
+		if err != nil {
+			return nil, goerr.Wrap(err, "fail to compile selection").With("name", name)
+		}
+		selections[name] = sel
+	}
+
+	p := &parser{tokens: tokenize(expr), selections: selections}
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, goerr.New("unexpected trailing token in condition").With("token", p.tokens[p.pos])
+	}
+
+	return cond, nil
+}
+
+// compileSelection compiles a single detection selection, which Sigma allows
+// to be either a mapping of field to value (an AND of field conditions) or a
+// list of such mappings (an OR of AND-blocks).
+func compileSelection(def any) (Condition, error) {
+	switch v := def.(type) {
+	case map[string]any:
+		return compileMapSelection(v)
+	case []any:
+		var or orCondition
+		for _, elem := range v {
+			m, ok := elem.(map[string]any)
+			if !ok {
+				return nil, goerr.New("selection list entries must be mappings of field to value")
+			}
+			cond, err := compileMapSelection(m)
+			if err != nil {
+				return nil, err
+			}
+			or = append(or, cond)
+		}
+		return or, nil
+	default:
+		return nil, goerr.New("selection must be a mapping of field to value, or a list of such mappings")
+	}
+}
+
+// compileMapSelection compiles a single field-to-value mapping into an AND of
+// fieldConditions. A field key may carry a "|"-separated chain of modifiers,
+// e.g. "CommandLine|contains|cased"; "cased" opts that field out of Sigma's
+// default case-insensitive matching, and the remaining token (if any) selects
+// the comparison mode ("eq" when none is given).
+func compileMapSelection(m map[string]any) (Condition, error) {
+	var and andCondition
+	for key, value := range m {
+		field := key
+		modifier := "eq"
+		cased := false
+
+		parts := strings.Split(key, "|")
+		field = parts[0]
+		for _, mod := range parts[1:] {
+			if mod == "cased" {
+				cased = true
+				continue
+			}
+			modifier = mod
+		}
+
+		fc := fieldCondition{field: field, modifier: modifier, value: value, cased: cased}
+
+		if modifier == "re" {
+			pattern := toString(value)
+			if values, ok := value.([]any); ok {
+				alts := make([]string, len(values))
+				for i, v := range values {
+					alts[i] = "(?:" + toString(v) + ")"
+				}
+				pattern = strings.Join(alts, "|")
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, goerr.Wrap(err, "fail to compile sigma regex modifier").With("field", field).With("pattern", pattern)
+			}
+			fc.re = re
+		}
+
+		and = append(and, fc)
+	}
+
+	return and, nil
+}
+
+func tokenize(expr string) []string {
+	expr = strings.ReplaceAll(expr, "(", " ( ")
+	expr = strings.ReplaceAll(expr, ")", " ) ")
+	return strings.Fields(expr)
+}
+
+type parser struct {
+	tokens     []string
+	pos        int
+	selections map[string]Condition
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) parseOr() (Condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := orCondition{left}
+	for strings.EqualFold(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, right)
+	}
+
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+func (p *parser) parseAnd() (Condition, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := andCondition{left}
+	for strings.EqualFold(p.peek(), "and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, right)
+	}
+
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+func (p *parser) parseNot() (Condition, error) {
+	if strings.EqualFold(p.peek(), "not") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notCondition{inner: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Condition, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return nil, goerr.New("unexpected end of condition expression")
+	case tok == "(":
+		cond, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, goerr.New("missing closing parenthesis in condition")
+		}
+		return cond, nil
+	case strings.HasSuffix(tok, "*"):
+		return p.parseWildcard(tok)
+	default:
+		sel, ok := p.selections[tok]
+		if !ok {
+			return nil, goerr.New("unknown selection in condition").With("name", tok)
+		}
+		return sel, nil
+	}
+}
+
+// parseWildcard supports Sigma patterns like "selection_*" that reference every
+// selection sharing a name prefix, ORing them together.
+func (p *parser) parseWildcard(pattern string) (Condition, error) {
+	prefix := strings.TrimSuffix(pattern, "*")
+
+	var matched orCondition
+	for name, cond := range p.selections {
+		if strings.HasPrefix(name, prefix) {
+			matched = append(matched, cond)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, goerr.New("wildcard selection matches nothing").With("pattern", pattern)
+	}
+
+	return matched, nil
+}
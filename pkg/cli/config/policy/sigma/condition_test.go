@@ -0,0 +1,159 @@
+package sigma
+
+import (
+	"testing"
+
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+)
+
+func TestCompileListValueModifiersOR(t *testing.T) {
+	cases := []struct {
+		name  string
+		def   map[string]any
+		row   model.Row
+		match bool
+	}{
+		{
+			name:  "contains matches second element",
+			def:   map[string]any{"CommandLine|contains": []any{"mimikatz", "procdump"}},
+			row:   model.Row{"CommandLine": "C:\\tools\\procdump.exe -ma lsass.exe"},
+			match: true,
+		},
+		{
+			name:  "contains matches none",
+			def:   map[string]any{"CommandLine|contains": []any{"mimikatz", "procdump"}},
+			row:   model.Row{"CommandLine": "notepad.exe"},
+			match: false,
+		},
+		{
+			name:  "startswith matches one of the list",
+			def:   map[string]any{"Image|startswith": []any{"C:\\Windows\\", "C:\\Temp\\"}},
+			row:   model.Row{"Image": "C:\\Temp\\evil.exe"},
+			match: true,
+		},
+		{
+			name:  "endswith matches one of the list",
+			def:   map[string]any{"Image|endswith": []any{".exe", ".dll"}},
+			row:   model.Row{"Image": "evil.dll"},
+			match: true,
+		},
+		{
+			name:  "bare equality matches one of the list",
+			def:   map[string]any{"EventID": []any{1, 2}},
+			row:   model.Row{"EventID": 2},
+			match: true,
+		},
+		{
+			name:  "all still requires every element",
+			def:   map[string]any{"CommandLine|all": []any{"-c", "evil"}},
+			row:   model.Row{"CommandLine": "-c evil"},
+			match: true,
+		},
+		{
+			name:  "all fails when one element is missing",
+			def:   map[string]any{"CommandLine|all": []any{"-c", "evil"}},
+			row:   model.Row{"CommandLine": "-c benign"},
+			match: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cond, err := compileMapSelection(tc.def)
+			if err != nil {
+				t.Fatalf("fail to compile selection: %v", err)
+			}
+			if got := cond.Eval(tc.row); got != tc.match {
+				t.Fatalf("Eval() = %v, want %v", got, tc.match)
+			}
+		})
+	}
+}
+
+func TestCompileMapSelectionRePrecompiledAtLoadTime(t *testing.T) {
+	cond, err := compileMapSelection(map[string]any{"Image|re": "^C:\\\\Windows\\\\.*\\.exe$"})
+	if err != nil {
+		t.Fatalf("fail to compile selection: %v", err)
+	}
+
+	fc, ok := cond.(andCondition)[0].(fieldCondition)
+	if !ok {
+		t.Fatalf("expected a fieldCondition, got %T", cond.(andCondition)[0])
+	}
+	if fc.re == nil {
+		t.Fatal("expected the regex to be compiled at load time")
+	}
+
+	if !cond.Eval(model.Row{"Image": "C:\\Windows\\evil.exe"}) {
+		t.Fatal("expected regex to match")
+	}
+	if cond.Eval(model.Row{"Image": "C:\\Temp\\evil.exe"}) {
+		t.Fatal("expected regex not to match")
+	}
+}
+
+func TestCompileMapSelectionInvalidRegexFailsAtLoadTime(t *testing.T) {
+	_, err := compileMapSelection(map[string]any{"Image|re": "("})
+	if err == nil {
+		t.Fatal("expected an error compiling an invalid regex at load time")
+	}
+}
+
+func TestCompileSelectionListOfMaps(t *testing.T) {
+	cond, err := compileSelection([]any{
+		map[string]any{"EventID": 1},
+		map[string]any{"EventID": 2},
+	})
+	if err != nil {
+		t.Fatalf("fail to compile selection: %v", err)
+	}
+
+	if !cond.Eval(model.Row{"EventID": 2}) {
+		t.Fatal("expected the second block to match")
+	}
+	if cond.Eval(model.Row{"EventID": 3}) {
+		t.Fatal("expected no block to match")
+	}
+}
+
+func TestFieldConditionCasedOptOut(t *testing.T) {
+	caseInsensitive, err := compileMapSelection(map[string]any{"Image|endswith": "POWERSHELL.EXE"})
+	if err != nil {
+		t.Fatalf("fail to compile selection: %v", err)
+	}
+	if !caseInsensitive.Eval(model.Row{"Image": "c:\\windows\\powershell.exe"}) {
+		t.Fatal("expected case-insensitive match by default")
+	}
+
+	cased, err := compileMapSelection(map[string]any{"Image|endswith|cased": "POWERSHELL.EXE"})
+	if err != nil {
+		t.Fatalf("fail to compile selection: %v", err)
+	}
+	if cased.Eval(model.Row{"Image": "c:\\windows\\powershell.exe"}) {
+		t.Fatal("expected cased modifier to require an exact-case match")
+	}
+}
+
+func TestCompileAndParseCondition(t *testing.T) {
+	detection := map[string]any{
+		"condition": "selection1 and not selection2",
+		"selection1": map[string]any{
+			"EventID": 1,
+		},
+		"selection2": map[string]any{
+			"User": "SYSTEM",
+		},
+	}
+
+	cond, err := compile(detection)
+	if err != nil {
+		t.Fatalf("fail to compile detection: %v", err)
+	}
+
+	if !cond.Eval(model.Row{"EventID": 1, "User": "alice"}) {
+		t.Fatal("expected match")
+	}
+	if cond.Eval(model.Row{"EventID": 1, "User": "SYSTEM"}) {
+		t.Fatal("expected no match when selection2 also matches")
+	}
+}
@@ -0,0 +1,154 @@
+// Package sigma implements a minimal Sigma (https://github.com/SigmaHQ/sigma)
+// rule engine that compiles detection rules into condition trees evaluable
+// against BigQuery result rows, so overseer can reuse Sigma detections
+// without rewriting them in Rego.
+package sigma
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/m-mizutani/goerr"
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is a parsed and compiled Sigma detection rule.
+type Rule struct {
+	Title       string         `yaml:"title"`
+	ID          string         `yaml:"id"`
+	Description string         `yaml:"description"`
+	Level       string         `yaml:"level"`
+	Tags        []string       `yaml:"tags"`
+	References  []string       `yaml:"references"`
+	Detection   map[string]any `yaml:"detection"`
+
+	condition Condition
+}
+
+// LoadDir reads every *.yml/*.yaml file under dir and compiles it into a Rule.
+func LoadDir(dir string) ([]*Rule, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, goerr.Wrap(err, "fail to read sigma rule directory").With("dir", dir)
+	}
+
+	var rules []*Rule
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		ext := filepath.Ext(e.Name())
+		if ext != ".yml" && ext != ".yaml" {
+			continue
+		}
+
+		rule, err := loadRuleFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func loadRuleFile(path string) (*Rule, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, goerr.Wrap(err, "fail to read sigma rule file").With("path", path)
+	}
+
+	var rule Rule
+	if err := yaml.Unmarshal(buf, &rule); err != nil {
+		return nil, goerr.Wrap(err, "fail to parse sigma rule file").With("path", path)
+	}
+
+	cond, err := compile(rule.Detection)
+	if err != nil {
+		return nil, goerr.Wrap(err, "fail to compile sigma rule condition").With("path", path).With("title", rule.Title)
+	}
+	rule.condition = cond
+
+	return &rule, nil
+}
+
+// Match reports whether row satisfies the rule's condition tree.
+func (x *Rule) Match(row model.Row) bool {
+	return x.condition.Eval(row)
+}
+
+// sigmaLevelToSeverity maps a Sigma rule's "level" field to overseer's Severity
+// enum. Sigma's "informational" has no direct overseer equivalent and maps to
+// SeverityInfo.
+var sigmaLevelToSeverity = map[string]model.Severity{
+	"informational": model.SeverityInfo,
+	"low":           model.SeverityLow,
+	"medium":        model.SeverityMedium,
+	"high":          model.SeverityHigh,
+	"critical":      model.SeverityCritical,
+}
+
+// attackTechniquePattern matches Sigma's "attack.t<technique>[.<sub-technique>]"
+// tag convention, e.g. "attack.t1059.001".
+var attackTechniquePattern = regexp.MustCompile(`^attack\.t(\d{4}(?:\.\d{3})?)$`)
+
+// parseAttackTags splits a Sigma rule's tags into MITRE ATT&CK technique IDs
+// and tactic names. Tags outside the "attack." namespace, and "attack."
+// tags that identify a group or software (e.g. "attack.g0006", "attack.s0002")
+// rather than a tactic, are ignored.
+func parseAttackTags(tags []string) (techniques []string, tactics []string) {
+	for _, tag := range tags {
+		lower := strings.ToLower(tag)
+
+		if m := attackTechniquePattern.FindStringSubmatch(lower); m != nil {
+			techniques = append(techniques, "T"+m[1])
+			continue
+		}
+
+		slug, ok := strings.CutPrefix(lower, "attack.")
+		if !ok || strings.ContainsAny(slug, "0123456789") {
+			continue
+		}
+
+		tactics = append(tactics, titleCase(slug))
+	}
+
+	return techniques, tactics
+}
+
+// titleCase converts a snake_case tactic slug (e.g. "lateral_movement") into
+// its MITRE ATT&CK tactic name ("Lateral Movement").
+func titleCase(slug string) string {
+	words := strings.Split(slug, "_")
+	for i, w := range words {
+		if w == "" {
+			continue
+		}
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// Alert converts the rule into an AlertBody for a row that matched it.
+func (x *Rule) Alert() model.AlertBody {
+	techniques, tactics := parseAttackTags(x.Tags)
+
+	return model.AlertBody{
+		Title:       x.Title,
+		Description: x.Description,
+		Severity:    sigmaLevelToSeverity[x.Level],
+		Techniques:  techniques,
+		Tactics:     tactics,
+		Attrs: model.Attrs{
+			"rule_id":    x.ID,
+			"level":      x.Level,
+			"tags":       x.Tags,
+			"references": x.References,
+		},
+	}
+}
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"context"
+	"time"
 
+	"github.com/m-mizutani/goerr"
 	"github.com/secmon-as-code/overseer/pkg/adaptor"
 	"github.com/secmon-as-code/overseer/pkg/cli/config/cache"
 	"github.com/secmon-as-code/overseer/pkg/cli/config/policy"
@@ -14,9 +16,12 @@ import (
 
 func cmdEval() *cli.Command {
 	var (
-		policyCfg policy.Config
-		cacheCfg  cache.Config
-		jobID     model.JobID
+		policyCfg   policy.Config
+		cacheCfg    cache.Config
+		jobID       model.JobID
+		dedupWindow time.Duration
+		dedupKeys   []string
+		alertFormat string
 	)
 
 	flags := []cli.Flag{
@@ -29,6 +34,28 @@ func cmdEval() *cli.Command {
 			Sources:     cli.NewValueSourceChain(cli.EnvVar("OVERSEER_JOB_ID")),
 			Required:    true,
 		},
+		&cli.DurationFlag{
+			Name:        "alert-dedup-window",
+			Usage:       "Window within which repeated alerts are aggregated instead of re-emitted, e.g. 1h (disabled if unset)",
+			Category:    "eval",
+			Destination: &dedupWindow,
+			Sources:     cli.NewValueSourceChain(cli.EnvVar("OVERSEER_ALERT_DEDUP_WINDOW")),
+		},
+		&cli.StringSliceFlag{
+			Name:        "dedup-key",
+			Usage:       "Attrs key to include in the alert dedup fingerprint, in addition to Title (repeatable)",
+			Category:    "eval",
+			Destination: &dedupKeys,
+			Sources:     cli.NewValueSourceChain(cli.EnvVar("OVERSEER_DEDUP_KEY")),
+		},
+		&cli.StringFlag{
+			Name:        "alert-format",
+			Usage:       "Alert output format: native or ocsf",
+			Category:    "eval",
+			Value:       string(model.AlertFormatNative),
+			Destination: &alertFormat,
+			Sources:     cli.NewValueSourceChain(cli.EnvVar("OVERSEER_ALERT_FORMAT")),
+		},
 	}
 	flags = append(flags, policyCfg.Flags()...)
 	flags = append(flags, cacheCfg.Flags()...)
@@ -46,9 +73,22 @@ func cmdEval() *cli.Command {
 			return err
 		}
 
+		var aggregator *usecase.Aggregator
+		if dedupWindow > 0 {
+			aggregator = usecase.NewAggregator(dedupWindow, dedupKeys)
+		}
+
+		format := model.AlertFormat(alertFormat)
+		if format != model.AlertFormatNative && format != model.AlertFormatOCSF {
+			return goerr.New("unknown alert format").With("format", alertFormat)
+		}
+
 		uc := usecase.New(adaptor.New())
 
-		return uc.Eval(ctx, policySvc, cacheSvc)
+		return uc.Eval(ctx, policySvc, cacheSvc, usecase.EvalOption{
+			Aggregator: aggregator,
+			Format:     format,
+		})
 	}
 
 	return &cli.Command{
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/m-mizutani/goerr"
+	"github.com/secmon-as-code/overseer/pkg/cli/config/policy"
+	"github.com/secmon-as-code/overseer/pkg/usecase"
+	"github.com/urfave/cli/v3"
+)
+
+func cmdTest() *cli.Command {
+	var (
+		policyCfg  policy.Config
+		fixtureDir string
+	)
+
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:        "fixture-dir",
+			Usage:       "Directory path of fixture files (mock rows and expected alerts)",
+			Category:    "test",
+			Destination: &fixtureDir,
+			Sources:     cli.NewValueSourceChain(cli.EnvVar("OVERSEER_FIXTURE_DIR")),
+			Required:    true,
+		},
+	}
+	flags = append(flags, policyCfg.Flags()...)
+
+	action := func(ctx context.Context, c *cli.Command) error {
+		policySvc, err := policyCfg.Build()
+		if err != nil {
+			return err
+		}
+
+		fixtures, err := usecase.LoadFixtures(fixtureDir)
+		if err != nil {
+			return err
+		}
+
+		var failed int
+		for _, fixture := range fixtures {
+			result, err := usecase.RunFixture(ctx, policySvc, fixture)
+			if err != nil {
+				return err
+			}
+
+			if result.Passed {
+				fmt.Printf("ok   %s\n", fixture.Name)
+				continue
+			}
+
+			failed++
+			fmt.Printf("FAIL %s\n%s\n", fixture.Name, result.Diff)
+		}
+
+		if failed > 0 {
+			return goerr.New("fixture assertions failed").With("failed", failed).With("total", len(fixtures))
+		}
+
+		return nil
+	}
+
+	return &cli.Command{
+		Name:   "test",
+		Usage:  "Evaluate policy fixtures against mock BigQuery rows and assert the expected alerts",
+		Flags:  flags,
+		Action: action,
+	}
+}
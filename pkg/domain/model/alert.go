@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
@@ -53,10 +54,48 @@ type AlertBody struct {
 
 	// Attrs is key-value pairs of additional information of alert.
 	Attrs Attrs `json:"attrs"`
+
+	// Severity classifies how urgently the alert should be handled. If empty,
+	// it's treated as SeverityUnknown. Notify adapters should prefer this field
+	// over any severity value stuffed into Attrs.
+	Severity Severity `json:"severity,omitempty"`
+
+	// Techniques is a set of MITRE ATT&CK technique IDs (e.g. "T1078.004")
+	// associated with the alert.
+	Techniques []string `json:"techniques,omitempty"`
+
+	// Tactics is a set of MITRE ATT&CK tactic names (e.g. "Initial Access")
+	// associated with the alert.
+	Tactics []string `json:"tactics,omitempty"`
 }
 
 type Attrs map[string]any
 
+// Severity classifies how urgently an alert should be handled.
+type Severity string
+
+const (
+	SeverityUnknown  Severity = ""
+	SeverityInfo     Severity = "info"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+func (x Severity) valid() bool {
+	switch x {
+	case SeverityUnknown, SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return true
+	default:
+		return false
+	}
+}
+
+// techniqueIDPattern matches MITRE ATT&CK technique IDs, with an optional
+// sub-technique suffix (e.g. "T1078" or "T1078.004").
+var techniqueIDPattern = regexp.MustCompile(`^T\d{4}(\.\d{3})?$`)
+
 func NewAlert(ctx context.Context, body AlertBody) (*Alert, error) {
 	var ts time.Time
 
@@ -111,5 +150,15 @@ func (x AlertBody) Validate() error {
 		return goerr.New("title is required")
 	}
 
+	if !x.Severity.valid() {
+		return goerr.New("unknown severity").With("severity", x.Severity)
+	}
+
+	for _, id := range x.Techniques {
+		if !techniqueIDPattern.MatchString(id) {
+			return goerr.New("malformed MITRE ATT&CK technique ID").With("technique", id)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/m-mizutani/goerr"
+)
+
+// AlertFormat selects how an Alert is serialized when it leaves overseer.
+type AlertFormat string
+
+const (
+	// AlertFormatNative serializes the Alert as-is.
+	AlertFormatNative AlertFormat = "native"
+
+	// AlertFormatOCSF converts the Alert into an OCSF Detection Finding event
+	// (class_uid 2004) so downstream SIEMs can consume it without a custom parser.
+	AlertFormatOCSF AlertFormat = "ocsf"
+)
+
+// Serialize encodes the alert as JSON in the given format. An empty format is
+// treated as AlertFormatNative.
+func (x *Alert) Serialize(format AlertFormat) ([]byte, error) {
+	switch format {
+	case "", AlertFormatNative:
+		return json.Marshal(x)
+
+	case AlertFormatOCSF:
+		buf, err := json.Marshal(x.toOCSF())
+		if err != nil {
+			return nil, goerr.Wrap(err, "fail to encode alert as OCSF")
+		}
+		return buf, nil
+
+	default:
+		return nil, goerr.New("unknown alert format").With("format", format)
+	}
+}
+
+// ocsfSchemaVersion is the OCSF schema version overseer's finding events declare.
+const ocsfSchemaVersion = "1.1.0"
+
+// ocsfDetectionFinding is a minimal OCSF Detection Finding event
+// (https://schema.ocsf.io/1.1.0/classes/detection_finding, class_uid 2004).
+type ocsfDetectionFinding struct {
+	ActivityID  int              `json:"activity_id"`
+	CategoryUID int              `json:"category_uid"`
+	ClassUID    int              `json:"class_uid"`
+	SeverityID  int              `json:"severity_id"`
+	Time        int64            `json:"time"`
+	Message     string           `json:"message"`
+	Metadata    ocsfMetadata     `json:"metadata"`
+	FindingInfo ocsfFindingInfo  `json:"finding_info"`
+	Enrichments []ocsfEnrichment `json:"enrichments,omitempty"`
+}
+
+type ocsfMetadata struct {
+	Version string `json:"version"`
+}
+
+type ocsfFindingInfo struct {
+	Title   string       `json:"title"`
+	UID     string       `json:"uid"`
+	Attacks []ocsfAttack `json:"attacks,omitempty"`
+}
+
+// ocsfAttack carries a MITRE ATT&CK technique/tactic pairing, following OCSF's
+// finding_info.attacks[] convention.
+type ocsfAttack struct {
+	Technique ocsfTechnique `json:"technique"`
+	Tactics   []string      `json:"tactics,omitempty"`
+}
+
+type ocsfTechnique struct {
+	UID string `json:"uid"`
+}
+
+type ocsfEnrichment struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ocsfSeverityByName maps Severity to an OCSF severity_id. SeverityUnknown
+// maps to 0 (Unknown).
+var ocsfSeverityByName = map[Severity]int{
+	SeverityInfo:     1,
+	SeverityLow:      2,
+	SeverityMedium:   3,
+	SeverityHigh:     4,
+	SeverityCritical: 5,
+}
+
+func (x *Alert) toOCSF() *ocsfDetectionFinding {
+	enrichments := make([]ocsfEnrichment, 0, len(x.Attrs))
+	for k, v := range x.Attrs {
+		enrichments = append(enrichments, ocsfEnrichment{Name: k, Value: fmt.Sprintf("%v", v)})
+	}
+	sort.Slice(enrichments, func(i, j int) bool { return enrichments[i].Name < enrichments[j].Name })
+
+	var attacks []ocsfAttack
+	for _, technique := range x.Techniques {
+		attacks = append(attacks, ocsfAttack{
+			Technique: ocsfTechnique{UID: technique},
+			Tactics:   x.Tactics,
+		})
+	}
+
+	return &ocsfDetectionFinding{
+		ActivityID:  0, // Unknown: overseer does not yet classify detection activity
+		CategoryUID: 2, // Findings
+		ClassUID:    2004,
+		SeverityID:  x.ocsfSeverityID(),
+		Time:        x.Timestamp.UnixMilli(),
+		Message:     x.Description,
+		Metadata:    ocsfMetadata{Version: ocsfSchemaVersion},
+		FindingInfo: ocsfFindingInfo{Title: x.Title, UID: string(x.ID), Attacks: attacks},
+		Enrichments: enrichments,
+	}
+}
+
+func (x *Alert) ocsfSeverityID() int {
+	return ocsfSeverityByName[x.Severity]
+}
@@ -0,0 +1,13 @@
+package model
+
+// QueryMeta describes the BigQuery query that produced a set of Row results.
+// It's used to derive the content-addressed cache key for the result set.
+type QueryMeta struct {
+	// SQL is the query text as sent to BigQuery.
+	SQL string
+
+	// SnapshotIDs identifies the state of every table the query reads (e.g.
+	// partition decorators or snapshot IDs), so a cache entry is invalidated
+	// once any referenced table changes.
+	SnapshotIDs []string
+}
@@ -0,0 +1,4 @@
+package model
+
+// Row is a single result row returned by a BigQuery query, keyed by column name.
+type Row map[string]any
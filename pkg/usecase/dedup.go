@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/m-mizutani/goerr"
+	"github.com/secmon-as-code/overseer/pkg/cli/config/cache"
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+)
+
+// maxDedupSamples bounds how many alert IDs are kept as Attrs["samples"] on a
+// summary alert.
+const maxDedupSamples = 5
+
+// Aggregator deduplicates repeated alerts within a rolling window, fingerprinted
+// by Title plus a configurable subset of Attrs keys. The first alert of a group
+// is emitted as-is; later matches within the window are folded into a running
+// counter instead of being emitted. Once the window has elapsed, the alert that
+// closes the group triggers a summary alert for the closed group if it held
+// more than one alert, and starts a new group of its own (emitted normally, as
+// the new group's first alert).
+type Aggregator struct {
+	window   time.Duration
+	attrKeys []string
+}
+
+// NewAggregator creates an Aggregator that groups alerts sharing a fingerprint
+// of Title plus the values of attrKeys, within window.
+func NewAggregator(window time.Duration, attrKeys []string) *Aggregator {
+	return &Aggregator{window: window, attrKeys: attrKeys}
+}
+
+// dedupState snapshots a group's identity (as of its first alert) plus its
+// running counter, so a summary can be built without borrowing fields from
+// whichever later alert happens to close the group.
+type dedupState struct {
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Attrs       model.Attrs `json:"attrs"`
+	Count       int         `json:"count"`
+	FirstSeen   time.Time   `json:"first_seen"`
+	LastSeen    time.Time   `json:"last_seen"`
+	Samples     []string    `json:"samples"`
+}
+
+// Dedup decides which alerts, if any, should be emitted for alert. It returns:
+//   - nil when alert is folded into its group's running count instead of being emitted;
+//   - [alert] when alert starts a new group (the group was never seen, or its
+//     window had already elapsed with only a single prior occurrence);
+//   - [summary, alert] when alert's arrival closes a group that had
+//     accumulated more than one occurrence: summary reports that closed
+//     group, and alert itself starts the new group.
+func (x *Aggregator) Dedup(ctx context.Context, cacheSvc cache.Service, alert *model.Alert) ([]*model.Alert, error) {
+	key := "dedup:" + x.fingerprint(alert)
+
+	raw, hit, err := cacheSvc.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var state dedupState
+	if hit {
+		if err := json.Unmarshal(raw, &state); err != nil {
+			return nil, goerr.Wrap(err, "fail to decode dedup state").With("key", key)
+		}
+	}
+
+	if !hit || alert.Timestamp.Sub(state.LastSeen) > x.window {
+		var out []*model.Alert
+
+		if hit && state.Count > 1 {
+			summary, err := x.summarize(ctx, state)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, summary)
+		}
+
+		if err := x.reset(ctx, cacheSvc, key, alert); err != nil {
+			return nil, err
+		}
+
+		return append(out, alert), nil
+	}
+
+	state.Count++
+	state.LastSeen = alert.Timestamp
+	if len(state.Samples) < maxDedupSamples {
+		state.Samples = append(state.Samples, string(alert.ID))
+	}
+
+	if err := x.save(ctx, cacheSvc, key, state); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (x *Aggregator) reset(ctx context.Context, cacheSvc cache.Service, key string, alert *model.Alert) error {
+	state := dedupState{
+		Title:       alert.Title,
+		Description: alert.Description,
+		Attrs:       alert.Attrs,
+		Count:       1,
+		FirstSeen:   alert.Timestamp,
+		LastSeen:    alert.Timestamp,
+		Samples:     []string{string(alert.ID)},
+	}
+
+	return x.save(ctx, cacheSvc, key, state)
+}
+
+func (x *Aggregator) save(ctx context.Context, cacheSvc cache.Service, key string, state dedupState) error {
+	buf, err := json.Marshal(state)
+	if err != nil {
+		return goerr.Wrap(err, "fail to encode dedup state")
+	}
+
+	if err := cacheSvc.Set(ctx, key, buf); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// summarize builds the alert emitted when a group's window closes, using only
+// the closed group's own recorded identity and accumulated count/samples -
+// never fields borrowed from the alert that happened to trigger the close.
+func (x *Aggregator) summarize(ctx context.Context, state dedupState) (*model.Alert, error) {
+	body := model.AlertBody{
+		Title:       state.Title,
+		Description: state.Description,
+		Attrs:       model.Attrs{},
+	}
+	for k, v := range state.Attrs {
+		body.Attrs[k] = v
+	}
+	body.Attrs["count"] = state.Count
+	body.Attrs["samples"] = state.Samples
+
+	return model.NewAlert(ctx, body)
+}
+
+// fingerprint derives a stable identifier for alert's dedup group from its
+// Title and the configured subset of Attrs keys.
+func (x *Aggregator) fingerprint(alert *model.Alert) string {
+	h := sha256.New()
+	h.Write([]byte(alert.Title))
+
+	keys := append([]string(nil), x.attrKeys...)
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		h.Write([]byte{0})
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+
+		if v, ok := alert.Attrs[k]; ok {
+			buf, _ := json.Marshal(v)
+			h.Write(buf)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/secmon-as-code/overseer/pkg/cli/config/cache"
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+)
+
+// memCache is a minimal in-memory cache.Service for exercising Aggregator
+// without touching disk.
+type memCache struct {
+	state map[string][]byte
+}
+
+func newMemCache() *memCache { return &memCache{state: map[string][]byte{}} }
+
+func (x *memCache) Key(meta model.QueryMeta) string { return "" }
+
+func (x *memCache) Load(ctx context.Context, key string) ([]model.Row, bool, error) {
+	return nil, false, nil
+}
+func (x *memCache) Save(ctx context.Context, key string, rows []model.Row) error { return nil }
+
+func (x *memCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	v, ok := x.state[key]
+	return v, ok, nil
+}
+
+func (x *memCache) Set(ctx context.Context, key string, value []byte) error {
+	x.state[key] = value
+	return nil
+}
+
+func (x *memCache) Stats(ctx context.Context) (cache.Stats, error) { return cache.Stats{}, nil }
+
+func mustAlert(t *testing.T, title string, ts time.Time) *model.Alert {
+	t.Helper()
+
+	alert, err := model.NewAlert(context.Background(), model.AlertBody{Title: title})
+	if err != nil {
+		t.Fatalf("fail to build alert: %v", err)
+	}
+	alert.Timestamp = ts
+
+	return alert
+}
+
+func TestAggregatorDedupWindowBoundary(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	agg := NewAggregator(time.Hour, nil)
+	cacheSvc := newMemCache()
+
+	a1 := mustAlert(t, "x", base)
+	out1, err := agg.Dedup(ctx, cacheSvc, a1)
+	if err != nil {
+		t.Fatalf("dedup a1: %v", err)
+	}
+	if len(out1) != 1 || out1[0] != a1 {
+		t.Fatalf("expected a1 to be emitted alone as the start of a new group, got %+v", out1)
+	}
+
+	// Within the window: folded into the running count, nothing emitted.
+	a2 := mustAlert(t, "x", base.Add(30*time.Minute))
+	out2, err := agg.Dedup(ctx, cacheSvc, a2)
+	if err != nil {
+		t.Fatalf("dedup a2: %v", err)
+	}
+	if out2 != nil {
+		t.Fatalf("expected a2 to be suppressed within the window, got %+v", out2)
+	}
+
+	// 65 minutes after a2 (the group's last-seen alert): the window has
+	// elapsed, so a3 must close the old group with a summary AND start its own
+	// new group - both emitted, in that order.
+	a3 := mustAlert(t, "x", base.Add(95*time.Minute))
+	out3, err := agg.Dedup(ctx, cacheSvc, a3)
+	if err != nil {
+		t.Fatalf("dedup a3: %v", err)
+	}
+	if len(out3) != 2 {
+		t.Fatalf("expected a summary plus a3 itself, got %d alerts: %+v", len(out3), out3)
+	}
+
+	summary := out3[0]
+	if summary.Title != "x" {
+		t.Fatalf("summary should carry the closed group's own title, got %q", summary.Title)
+	}
+	if summary.Attrs["count"] != 2 {
+		t.Fatalf("summary should report the closed group's count of 2, got %v", summary.Attrs["count"])
+	}
+	samples, _ := summary.Attrs["samples"].([]string)
+	if len(samples) != 2 || samples[0] != string(a1.ID) || samples[1] != string(a2.ID) {
+		t.Fatalf("summary should carry the closed group's own samples, got %v", samples)
+	}
+
+	if out3[1] != a3 {
+		t.Fatalf("a3 itself should be emitted as the first alert of its own new group")
+	}
+
+	// a4 immediately after a3: still within the new group's window, folded.
+	a4 := mustAlert(t, "x", a3.Timestamp)
+	out4, err := agg.Dedup(ctx, cacheSvc, a4)
+	if err != nil {
+		t.Fatalf("dedup a4: %v", err)
+	}
+	if out4 != nil {
+		t.Fatalf("expected a4 to be suppressed within a3's new window, got %+v", out4)
+	}
+}
+
+func TestAggregatorDedupCacheMissRecovery(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	agg := NewAggregator(time.Hour, nil)
+	cacheSvc := newMemCache()
+
+	a1 := mustAlert(t, "x", base)
+	if _, err := agg.Dedup(ctx, cacheSvc, a1); err != nil {
+		t.Fatalf("dedup a1: %v", err)
+	}
+
+	// Simulate the cache losing the group's state (e.g. evicted between runs).
+	key := "dedup:" + agg.fingerprint(a1)
+	delete(cacheSvc.state, key)
+
+	// a2 would normally be within the window, but with state gone it must be
+	// treated as a fresh group instead of erroring out.
+	a2 := mustAlert(t, "x", base.Add(time.Minute))
+	out, err := agg.Dedup(ctx, cacheSvc, a2)
+	if err != nil {
+		t.Fatalf("dedup a2 after cache miss: %v", err)
+	}
+	if len(out) != 1 || out[0] != a2 {
+		t.Fatalf("expected a2 to recover as a new group on its own, got %+v", out)
+	}
+}
@@ -0,0 +1,88 @@
+// Package usecase implements overseer's application logic on top of the
+// adaptors and services configured by the CLI commands.
+package usecase
+
+import (
+	"context"
+
+	"github.com/secmon-as-code/overseer/pkg/adaptor"
+	"github.com/secmon-as-code/overseer/pkg/cli/config/cache"
+	"github.com/secmon-as-code/overseer/pkg/cli/config/policy"
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+)
+
+// UseCase implements overseer's application logic on top of external adaptors.
+type UseCase struct {
+	adaptor *adaptor.Adaptor
+}
+
+// New creates a UseCase bound to the given adaptor.
+func New(adaptor *adaptor.Adaptor) *UseCase {
+	return &UseCase{adaptor: adaptor}
+}
+
+// EvalOption configures optional behavior of UseCase.Eval.
+type EvalOption struct {
+	// Aggregator, when non-nil, deduplicates alerts before they are notified.
+	Aggregator *Aggregator
+
+	// Format selects the wire format alerts are notified in. Zero value is
+	// model.AlertFormatNative.
+	Format model.AlertFormat
+}
+
+// Eval runs the configured BigQuery query (or replays it from cacheSvc),
+// evaluates each result row against policySvc and dispatches any raised alerts.
+func (x *UseCase) Eval(ctx context.Context, policySvc policy.Service, cacheSvc cache.Service, opt EvalOption) error {
+	meta, err := x.adaptor.Describe(ctx)
+	if err != nil {
+		return err
+	}
+	key := cacheSvc.Key(meta)
+
+	rows, hit, err := cacheSvc.Load(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if !hit {
+		rows, err = x.adaptor.Query(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := cacheSvc.Save(ctx, key, rows); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		alerts, err := policySvc.Evaluate(ctx, row)
+		if err != nil {
+			return err
+		}
+
+		for _, alert := range alerts {
+			toEmit := []*model.Alert{alert}
+			if opt.Aggregator != nil {
+				toEmit, err = opt.Aggregator.Dedup(ctx, cacheSvc, alert)
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, a := range toEmit {
+				payload, err := a.Serialize(opt.Format)
+				if err != nil {
+					return err
+				}
+
+				if err := x.adaptor.Notify(ctx, payload); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
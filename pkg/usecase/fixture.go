@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/m-mizutani/goerr"
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture describes a mock BigQuery result set and the alerts that evaluating
+// it against the configured policies is expected to produce.
+type Fixture struct {
+	// Name identifies the fixture in test output; it's the fixture file's name
+	// without its extension.
+	Name string `yaml:"-"`
+
+	// Rows is the mock BigQuery result set fed into the policy evaluator.
+	Rows []model.Row `yaml:"rows"`
+
+	// Expect is the set of alert outcomes the fixture asserts.
+	Expect []ExpectedAlert `yaml:"expect"`
+}
+
+// ExpectedAlert asserts that evaluating a Fixture's rows produces Count alerts
+// titled Title whose Attrs are a superset of Attrs.
+type ExpectedAlert struct {
+	Title string      `yaml:"title"`
+	Attrs model.Attrs `yaml:"attrs"`
+	Count int         `yaml:"count"`
+}
+
+// LoadFixtures reads every *.yml/*.yaml file under dir into a Fixture.
+func LoadFixtures(dir string) ([]*Fixture, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, goerr.Wrap(err, "fail to read fixture directory").With("dir", dir)
+	}
+
+	var fixtures []*Fixture
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		ext := filepath.Ext(e.Name())
+		if ext != ".yml" && ext != ".yaml" {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return nil, goerr.Wrap(err, "fail to read fixture file").With("path", path)
+		}
+
+		var fixture Fixture
+		if err := yaml.Unmarshal(buf, &fixture); err != nil {
+			return nil, goerr.Wrap(err, "fail to parse fixture file").With("path", path)
+		}
+		fixture.Name = strings.TrimSuffix(e.Name(), ext)
+
+		fixtures = append(fixtures, &fixture)
+	}
+
+	return fixtures, nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/secmon-as-code/overseer/pkg/cli/config/policy"
+	"github.com/secmon-as-code/overseer/pkg/domain/model"
+)
+
+// TestResult is the outcome of running one Fixture's rows through a Service.
+type TestResult struct {
+	Fixture *Fixture
+	Passed  bool
+
+	// Diff is a unified-diff-style report of the mismatches between
+	// Fixture.Expect and the alerts actually produced. It's empty when Passed.
+	Diff string
+}
+
+// RunFixture evaluates every row in fixture against policySvc using the same
+// evaluation path as UseCase.Eval, then diffs the produced alerts against
+// fixture.Expect.
+func RunFixture(ctx context.Context, policySvc policy.Service, fixture *Fixture) (*TestResult, error) {
+	var alerts []*model.Alert
+	for _, row := range fixture.Rows {
+		found, err := policySvc.Evaluate(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, found...)
+	}
+
+	diff := diffAlerts(fixture.Expect, alerts)
+
+	return &TestResult{Fixture: fixture, Passed: diff == "", Diff: diff}, nil
+}
+
+// diffAlerts compares expected alert outcomes against the alerts actually
+// produced and renders any mismatch as "-"/"+" lines, unified-diff style.
+func diffAlerts(expected []ExpectedAlert, actual []*model.Alert) string {
+	var lines []string
+
+	expectedTitles := map[string]bool{}
+	for _, exp := range expected {
+		expectedTitles[exp.Title] = true
+
+		var matching []*model.Alert
+		for _, a := range actual {
+			if a.Title == exp.Title {
+				matching = append(matching, a)
+			}
+		}
+
+		if len(matching) != exp.Count {
+			lines = append(lines,
+				fmt.Sprintf("- title=%q count=%d", exp.Title, exp.Count),
+				fmt.Sprintf("+ title=%q count=%d", exp.Title, len(matching)),
+			)
+			continue
+		}
+
+		for k, want := range exp.Attrs {
+			for _, a := range matching {
+				got, ok := a.Attrs[k]
+				if !ok || fmt.Sprintf("%v", got) != fmt.Sprintf("%v", want) {
+					lines = append(lines,
+						fmt.Sprintf("- title=%q attrs[%s]=%v", exp.Title, k, want),
+						fmt.Sprintf("+ title=%q attrs[%s]=%v", exp.Title, k, got),
+					)
+				}
+			}
+		}
+	}
+
+	// Flag alerts whose title isn't expected at all, so a rule that starts
+	// over-firing is caught even when Fixture.Expect never mentions its title.
+	unexpectedCounts := map[string]int{}
+	for _, a := range actual {
+		if !expectedTitles[a.Title] {
+			unexpectedCounts[a.Title]++
+		}
+	}
+
+	unexpectedTitles := make([]string, 0, len(unexpectedCounts))
+	for title := range unexpectedCounts {
+		unexpectedTitles = append(unexpectedTitles, title)
+	}
+	sort.Strings(unexpectedTitles)
+
+	for _, title := range unexpectedTitles {
+		lines = append(lines, fmt.Sprintf("+ title=%q count=%d (unexpected)", title, unexpectedCounts[title]))
+	}
+
+	return strings.Join(lines, "\n")
+}